@@ -0,0 +1,140 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/ssm"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// ConfigArgs configures ConfigComponent. Callers pass already-computed
+// connection strings/secrets rather than raw resources, so this component
+// doesn't need to know about RDS, ElastiCache, or EKS directly.
+type ConfigArgs struct {
+	Environment string
+	// Region, when set, is inserted into every parameter path as
+	// /origin/<environment>/<region>/... so regional stacks don't clobber
+	// each other's parameters. Empty for a single-region deployment.
+	Region         string
+	DatabaseURL    pulumi.StringInput
+	RedisURL       pulumi.StringInput
+	JWTSecret      pulumi.StringInput
+	ClaudeAPIKey   pulumi.StringInput
+	EKSClusterName pulumi.StringInput
+	EKSKubeconfig  pulumi.StringInput
+}
+
+// ConfigOutputs holds the SSM parameter names so callers can export them.
+type ConfigOutputs struct {
+	DbUrlParameterName          pulumi.StringOutput
+	RedisUrlParameterName       pulumi.StringOutput
+	JwtSecretParameterName      pulumi.StringOutput
+	ClaudeApiKeyParameterName   pulumi.StringOutput
+	EksClusterNameParameterName pulumi.StringOutput
+	EksKubeconfigParameterName  pulumi.StringOutput
+}
+
+// ConfigComponent writes all of Origin's runtime configuration to SSM
+// Parameter Store under /origin/<environment>/...
+type ConfigComponent struct {
+	pulumi.ResourceState
+
+	Outputs ConfigOutputs
+}
+
+// NewConfigComponent builds the SSM parameters and registers the component
+// as "origin:components:ConfigComponent".
+func NewConfigComponent(ctx *pulumi.Context, name string, args *ConfigArgs, opts ...pulumi.ResourceOption) (*ConfigComponent, error) {
+	c := &ConfigComponent{}
+	if err := ctx.RegisterComponentResource("origin:components:ConfigComponent", name, c, opts...); err != nil {
+		return nil, err
+	}
+	parent := pulumi.Parent(c)
+
+	// paramPath builds /origin/<environment>/[<region>/]<suffix>, keyed by
+	// region so sibling regional stacks don't overwrite each other's
+	// parameters.
+	paramPath := func(suffix string) pulumi.StringOutput {
+		if args.Region != "" {
+			return pulumi.Sprintf("/origin/%s/%s/%s", args.Environment, args.Region, suffix)
+		}
+		return pulumi.Sprintf("/origin/%s/%s", args.Environment, suffix)
+	}
+
+	dbUrlParam, err := ssm.NewParameter(ctx, fmt.Sprintf("%s-db-url", name), &ssm.ParameterArgs{
+		Name:        paramPath("database-url"),
+		Type:        pulumi.String("SecureString"),
+		Value:       args.DatabaseURL,
+		Description: pulumi.String("Database connection URL from RDS"),
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	redisUrlParam, err := ssm.NewParameter(ctx, fmt.Sprintf("%s-redis-url", name), &ssm.ParameterArgs{
+		Name:        paramPath("redis-url"),
+		Type:        pulumi.String("String"),
+		Value:       args.RedisURL,
+		Description: pulumi.String("Redis connection URL from ElastiCache"),
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	jwtSecretParam, err := ssm.NewParameter(ctx, fmt.Sprintf("%s-jwt-secret", name), &ssm.ParameterArgs{
+		Name:        paramPath("jwt-secret"),
+		Type:        pulumi.String("SecureString"),
+		Value:       args.JWTSecret,
+		Description: pulumi.String("JWT signing secret"),
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	claudeApiKeyParam, err := ssm.NewParameter(ctx, fmt.Sprintf("%s-claude-api-key", name), &ssm.ParameterArgs{
+		Name:        paramPath("claude-api-key"),
+		Type:        pulumi.String("SecureString"),
+		Value:       args.ClaudeAPIKey,
+		Description: pulumi.String("Claude AI API key"),
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	eksClusterNameParam, err := ssm.NewParameter(ctx, fmt.Sprintf("%s-eks-cluster-name", name), &ssm.ParameterArgs{
+		Name:        paramPath("eks/cluster-name"),
+		Type:        pulumi.String("String"),
+		Value:       args.EKSClusterName,
+		Description: pulumi.String("EKS cluster name for the Origin app"),
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	eksKubeconfigParam, err := ssm.NewParameter(ctx, fmt.Sprintf("%s-eks-kubeconfig", name), &ssm.ParameterArgs{
+		Name:        paramPath("eks/kubeconfig"),
+		Type:        pulumi.String("SecureString"),
+		Value:       args.EKSKubeconfig,
+		Description: pulumi.String("kubeconfig for the Origin EKS cluster"),
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Outputs = ConfigOutputs{
+		DbUrlParameterName:          dbUrlParam.Name,
+		RedisUrlParameterName:       redisUrlParam.Name,
+		JwtSecretParameterName:      jwtSecretParam.Name,
+		ClaudeApiKeyParameterName:   claudeApiKeyParam.Name,
+		EksClusterNameParameterName: eksClusterNameParam.Name,
+		EksKubeconfigParameterName:  eksKubeconfigParam.Name,
+	}
+
+	if err := ctx.RegisterResourceOutputs(c, pulumi.Map{
+		"dbUrlParameterName": c.Outputs.DbUrlParameterName,
+	}); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,216 @@
+package components
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/ec2"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/elasticache"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/rds"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/edo-chan/origin/pkg/secrets"
+	"github.com/edo-chan/origin/pkg/sg"
+)
+
+// DataArgs configures DataComponent.
+type DataArgs struct {
+	Environment      string
+	VpcID            pulumi.StringInput
+	PrivateSubnetIDs pulumi.StringArrayInput
+
+	// NodeSecurityGroupID, when set, is granted default ingress to the db
+	// and cache security groups (5432 and 6379 respectively) so the EKS
+	// node group the app runs on can reach RDS/ElastiCache without every
+	// environment having to populate dbSecurityGroupRules/
+	// cacheSecurityGroupRules by hand. Additional sources can still be
+	// layered on top via those config keys.
+	NodeSecurityGroupID pulumi.StringInput
+
+	// Region is appended to Environment in resource and tag names when this
+	// component is one of several regional instances; empty for a
+	// single-region deployment.
+	Region string
+	// DbInstanceClass and CacheNodeType override the usual free-tier
+	// defaults; empty means "use the default".
+	DbInstanceClass string
+	CacheNodeType   string
+}
+
+// DataOutputs is what ConfigComponent needs to publish connection info.
+type DataOutputs struct {
+	PostgresEndpoint pulumi.StringOutput
+	PostgresPort     pulumi.IntOutput
+	RedisEndpoint    pulumi.StringOutput
+	RedisPort        pulumi.IntOutput
+	DatabaseURL      pulumi.StringOutput
+	RedisURL         pulumi.StringOutput
+}
+
+// DataComponent provisions RDS PostgreSQL and ElastiCache Redis, their
+// subnet groups, and their config-driven security groups.
+type DataComponent struct {
+	pulumi.ResourceState
+
+	DbInstance   *rds.Instance
+	RedisCluster *elasticache.ReplicationGroup
+	Outputs      DataOutputs
+}
+
+// NewDataComponent builds the data subsystem and registers it as
+// "origin:components:DataComponent".
+func NewDataComponent(ctx *pulumi.Context, name string, args *DataArgs, opts ...pulumi.ResourceOption) (*DataComponent, error) {
+	c := &DataComponent{}
+	if err := ctx.RegisterComponentResource("origin:components:DataComponent", name, c, opts...); err != nil {
+		return nil, err
+	}
+	parent := pulumi.Parent(c)
+
+	namePrefix := args.Environment
+	if args.Region != "" {
+		namePrefix = fmt.Sprintf("%s-%s", args.Environment, args.Region)
+	}
+
+	dbInstanceClass := args.DbInstanceClass
+	if dbInstanceClass == "" {
+		dbInstanceClass = "db.t3.micro" // Free tier eligible
+	}
+	cacheNodeType := args.CacheNodeType
+	if cacheNodeType == "" {
+		cacheNodeType = "cache.t3.micro" // Free tier eligible
+	}
+
+	dbSecurityGroup, err := sg.NewSecurityGroupFromConfig(ctx, fmt.Sprintf("%s-origin-db-sg", namePrefix), args.VpcID, "dbSecurityGroupRules", parent)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheSecurityGroup, err := sg.NewSecurityGroupFromConfig(ctx, fmt.Sprintf("%s-origin-cache-sg", namePrefix), args.VpcID, "cacheSecurityGroupRules", parent)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.NodeSecurityGroupID != nil {
+		if _, err := ec2.NewSecurityGroupRule(ctx, fmt.Sprintf("%s-db-sg-ingress-from-nodes", name), &ec2.SecurityGroupRuleArgs{
+			Type:                  pulumi.String("ingress"),
+			SecurityGroupId:       dbSecurityGroup.ID(),
+			Protocol:              pulumi.String("tcp"),
+			FromPort:              pulumi.Int(5432),
+			ToPort:                pulumi.Int(5432),
+			SourceSecurityGroupId: args.NodeSecurityGroupID,
+			Description:           pulumi.String("EKS node group access to Postgres"),
+		}, parent); err != nil {
+			return nil, err
+		}
+
+		if _, err := ec2.NewSecurityGroupRule(ctx, fmt.Sprintf("%s-cache-sg-ingress-from-nodes", name), &ec2.SecurityGroupRuleArgs{
+			Type:                  pulumi.String("ingress"),
+			SecurityGroupId:       cacheSecurityGroup.ID(),
+			Protocol:              pulumi.String("tcp"),
+			FromPort:              pulumi.Int(6379),
+			ToPort:                pulumi.Int(6379),
+			SourceSecurityGroupId: args.NodeSecurityGroupID,
+			Description:           pulumi.String("EKS node group access to Redis"),
+		}, parent); err != nil {
+			return nil, err
+		}
+	}
+
+	dbSubnetGroup, err := rds.NewSubnetGroup(ctx, fmt.Sprintf("%s-db-subnet-group", name), &rds.SubnetGroupArgs{
+		Name:      pulumi.Sprintf("%s-origin-db-subnet-group", namePrefix),
+		SubnetIds: args.PrivateSubnetIDs,
+		Tags: pulumi.StringMap{
+			"Name": pulumi.Sprintf("%s-origin-db-subnet-group", namePrefix),
+		},
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheSubnetGroup, err := elasticache.NewSubnetGroup(ctx, fmt.Sprintf("%s-cache-subnet-group", name), &elasticache.SubnetGroupArgs{
+		Name:      pulumi.Sprintf("%s-origin-cache-subnet-group", namePrefix),
+		SubnetIds: args.PrivateSubnetIDs,
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	rdsPassword, err := secrets.NewDatabasePassword(ctx, fmt.Sprintf("%s-postgres-db-password", name), parent)
+	if err != nil {
+		return nil, err
+	}
+
+	dbInstance, err := rds.NewInstance(ctx, fmt.Sprintf("%s-postgres-db", name), &rds.InstanceArgs{
+		AllocatedStorage:    pulumi.Int(20),
+		StorageType:         pulumi.String("gp2"),
+		Engine:              pulumi.String("postgres"),
+		EngineVersion:       pulumi.String("15.7"),
+		InstanceClass:       pulumi.String(dbInstanceClass),
+		DbName:              pulumi.String("origin"),
+		Username:            pulumi.String("postgres"),
+		Password:            rdsPassword.Result,
+		VpcSecurityGroupIds: pulumi.StringArray{dbSecurityGroup.ID()},
+		DbSubnetGroupName:   dbSubnetGroup.Name,
+		SkipFinalSnapshot:   pulumi.Bool(true),
+		PubliclyAccessible:  pulumi.Bool(false),
+		Tags: pulumi.StringMap{
+			"Name":        pulumi.Sprintf("%s-origin-postgres", namePrefix),
+			"Environment": pulumi.String(args.Environment),
+		},
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+	c.DbInstance = dbInstance
+
+	redisCluster, err := elasticache.NewReplicationGroup(ctx, fmt.Sprintf("%s-redis-cluster", name), &elasticache.ReplicationGroupArgs{
+		ReplicationGroupId:       pulumi.Sprintf("%s-origin-redis", namePrefix),
+		Description:              pulumi.String("Redis cluster for Origin app"),
+		NodeType:                 pulumi.String(cacheNodeType),
+		Engine:                   pulumi.String("redis"),
+		EngineVersion:            pulumi.String("7.0"),
+		Port:                     pulumi.Int(6379),
+		NumCacheClusters:         pulumi.Int(1),
+		SecurityGroupIds:         pulumi.StringArray{cacheSecurityGroup.ID()},
+		SubnetGroupName:          cacheSubnetGroup.Name,
+		AtRestEncryptionEnabled:  pulumi.Bool(true),
+		TransitEncryptionEnabled: pulumi.Bool(false), // Simplified for development
+		Tags: pulumi.StringMap{
+			"Name":        pulumi.Sprintf("%s-origin-redis", namePrefix),
+			"Environment": pulumi.String(args.Environment),
+		},
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+	c.RedisCluster = redisCluster
+
+	databaseURL := pulumi.ToSecret(pulumi.All(dbInstance.Endpoint, rdsPassword.Result).ApplyT(
+		func(a []interface{}) string {
+			u := url.URL{
+				Scheme: "postgresql",
+				User:   url.UserPassword("postgres", a[1].(string)),
+				Host:   fmt.Sprintf("%s:5432", a[0].(string)),
+				Path:   "/origin",
+			}
+			return u.String()
+		}).(pulumi.StringOutput)).(pulumi.StringOutput)
+
+	c.Outputs = DataOutputs{
+		PostgresEndpoint: dbInstance.Endpoint,
+		PostgresPort:     dbInstance.Port,
+		RedisEndpoint:    redisCluster.ConfigurationEndpointAddress,
+		RedisPort:        redisCluster.Port,
+		DatabaseURL:      databaseURL,
+		RedisURL:         pulumi.Sprintf("redis://%s:6379", redisCluster.ConfigurationEndpointAddress),
+	}
+
+	if err := ctx.RegisterResourceOutputs(c, pulumi.Map{
+		"postgresEndpoint": c.Outputs.PostgresEndpoint,
+		"redisEndpoint":    c.Outputs.RedisEndpoint,
+	}); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,67 @@
+package components
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+type dataComponentMocks int
+
+func (dataComponentMocks) NewResource(args pulumi.MockResourceArgs) (string, resource.PropertyMap, error) {
+	return args.Name + "_id", args.Inputs, nil
+}
+
+func (dataComponentMocks) Call(args pulumi.MockCallArgs) (resource.PropertyMap, error) {
+	return args.Args, nil
+}
+
+func TestNewDataComponent(t *testing.T) {
+	cases := []struct {
+		name        string
+		environment string
+	}{
+		{name: "dev environment", environment: "dev"},
+		{name: "prod environment", environment: "prod"},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var wg sync.WaitGroup
+			wg.Add(1)
+
+			// No dbSecurityGroupRules/cacheSecurityGroupRules config is
+			// supplied; NewSecurityGroupFromConfig treats that key as
+			// optional and creates the groups with no ingress rules, so
+			// this runs without a full stack config.
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				data, err := NewDataComponent(ctx, "test-data", &DataArgs{
+					Environment:      tc.environment,
+					VpcID:            pulumi.String("vpc-12345"),
+					PrivateSubnetIDs: pulumi.StringArray{pulumi.String("subnet-1"), pulumi.String("subnet-2")},
+				})
+				if err != nil {
+					return err
+				}
+
+				data.DbInstance.VpcSecurityGroupIds.ApplyT(func(ids []string) error {
+					defer wg.Done()
+					if len(ids) != 1 {
+						t.Errorf("expected exactly one db security group id, got %d", len(ids))
+					}
+					return nil
+				})
+
+				return nil
+			}, pulumi.WithMocks("origin-test", "test", dataComponentMocks(0)))
+			if err != nil {
+				t.Fatalf("NewDataComponent: %v", err)
+			}
+
+			wg.Wait()
+		})
+	}
+}
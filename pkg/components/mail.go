@@ -0,0 +1,107 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/route53"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/ses"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// MailArgs configures MailComponent.
+type MailArgs struct {
+	Environment string
+	Domain      string
+	// HostedZoneID is optional; when set, Route53 records proving domain
+	// and DKIM ownership are created automatically instead of requiring a
+	// human to copy them from the SES console.
+	HostedZoneID string
+}
+
+// MailOutputs is what downstream stacks/consumers need from SES.
+type MailOutputs struct {
+	IdentityArn             pulumi.StringOutput
+	DomainVerificationToken pulumi.StringOutput
+	DkimTokens              pulumi.StringArrayOutput
+	ConfigSetName           pulumi.StringOutput
+}
+
+// MailComponent provisions an SES domain identity, its DKIM tokens, a
+// configuration set, and (if a hosted zone id is configured) the Route53
+// records that verify both.
+type MailComponent struct {
+	pulumi.ResourceState
+
+	Outputs MailOutputs
+}
+
+// NewMailComponent builds the mail subsystem and registers it as
+// "origin:components:MailComponent".
+func NewMailComponent(ctx *pulumi.Context, name string, args *MailArgs, opts ...pulumi.ResourceOption) (*MailComponent, error) {
+	c := &MailComponent{}
+	if err := ctx.RegisterComponentResource("origin:components:MailComponent", name, c, opts...); err != nil {
+		return nil, err
+	}
+	parent := pulumi.Parent(c)
+
+	sesIdentity, err := ses.NewDomainIdentity(ctx, fmt.Sprintf("%s-ses-domain", name), &ses.DomainIdentityArgs{
+		Domain: pulumi.String(args.Domain),
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	sesDkim, err := ses.NewDomainDkim(ctx, fmt.Sprintf("%s-ses-dkim", name), &ses.DomainDkimArgs{
+		Domain: sesIdentity.Domain,
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	sesConfigSet, err := ses.NewConfigurationSet(ctx, fmt.Sprintf("%s-ses-config-set", name), &ses.ConfigurationSetArgs{
+		Name: pulumi.Sprintf("%s-origin-config-set", args.Environment),
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.HostedZoneID != "" {
+		if _, err := route53.NewRecord(ctx, fmt.Sprintf("%s-ses-verification-record", name), &route53.RecordArgs{
+			ZoneId:  pulumi.String(args.HostedZoneID),
+			Name:    pulumi.Sprintf("_amazonses.%s", args.Domain),
+			Type:    pulumi.String("TXT"),
+			Ttl:     pulumi.Int(600),
+			Records: pulumi.StringArray{sesIdentity.VerificationToken},
+		}, parent); err != nil {
+			return nil, err
+		}
+
+		for i := 0; i < 3; i++ {
+			token := sesDkim.DkimTokens.Index(pulumi.Int(i))
+			if _, err := route53.NewRecord(ctx, fmt.Sprintf("%s-ses-dkim-record-%d", name, i), &route53.RecordArgs{
+				ZoneId:  pulumi.String(args.HostedZoneID),
+				Name:    pulumi.Sprintf("%s._domainkey.%s", token, args.Domain),
+				Type:    pulumi.String("CNAME"),
+				Ttl:     pulumi.Int(600),
+				Records: pulumi.StringArray{pulumi.Sprintf("%s.dkim.amazonses.com", token)},
+			}, parent); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	c.Outputs = MailOutputs{
+		IdentityArn:             sesIdentity.Arn,
+		DomainVerificationToken: sesIdentity.VerificationToken,
+		DkimTokens:              sesDkim.DkimTokens,
+		ConfigSetName:           sesConfigSet.Name,
+	}
+
+	if err := ctx.RegisterResourceOutputs(c, pulumi.Map{
+		"sesIdentityArn": c.Outputs.IdentityArn,
+	}); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,69 @@
+// Package components holds the top-level ComponentResources that make up
+// the Origin Pulumi stack: network, data, mail, and config, wired together
+// by OriginStack. Splitting main's ~30 sequential resource calls into these
+// lets each subsystem preview independently and be unit tested with mocks.
+package components
+
+import (
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/edo-chan/origin/pkg/network"
+)
+
+// NetworkArgs configures NetworkComponent.
+type NetworkArgs struct {
+	Environment    string
+	CidrBlock      string
+	AzCount        int
+	EnableFlowLogs bool
+}
+
+// NetworkOutputs is what the other components need from the network.
+type NetworkOutputs struct {
+	VpcID            pulumi.IDOutput
+	PublicSubnetIDs  pulumi.StringArrayOutput
+	PrivateSubnetIDs pulumi.StringArrayOutput
+	NatGatewayEIP    pulumi.StringOutput
+}
+
+// NetworkComponent wraps pkg/network's VPC subsystem so OriginStack can
+// treat it as a single child resource in the stack's dependency graph.
+type NetworkComponent struct {
+	pulumi.ResourceState
+
+	Outputs NetworkOutputs
+}
+
+// NewNetworkComponent builds the VPC subsystem and registers it as
+// "origin:components:NetworkComponent".
+func NewNetworkComponent(ctx *pulumi.Context, name string, args *NetworkArgs, opts ...pulumi.ResourceOption) (*NetworkComponent, error) {
+	c := &NetworkComponent{}
+	if err := ctx.RegisterComponentResource("origin:components:NetworkComponent", name, c, opts...); err != nil {
+		return nil, err
+	}
+
+	net, err := network.NewNetwork(ctx, name+"-net", &network.Args{
+		Environment:    args.Environment,
+		CidrBlock:      args.CidrBlock,
+		AzCount:        args.AzCount,
+		EnableFlowLogs: args.EnableFlowLogs,
+	}, pulumi.Parent(c))
+	if err != nil {
+		return nil, err
+	}
+
+	c.Outputs = NetworkOutputs{
+		VpcID:            net.Vpc.ID(),
+		PublicSubnetIDs:  net.PublicSubnetIDs,
+		PrivateSubnetIDs: net.PrivateSubnetIDs,
+		NatGatewayEIP:    net.NatGatewayEIP,
+	}
+
+	if err := ctx.RegisterResourceOutputs(c, pulumi.Map{
+		"vpcId": c.Outputs.VpcID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,173 @@
+package components
+
+import (
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/config"
+
+	"github.com/edo-chan/origin/pkg/compute/eks"
+	"github.com/edo-chan/origin/pkg/secrets"
+)
+
+// StackArgs configures OriginStack.
+type StackArgs struct {
+	Environment string
+	Domain      string
+
+	// Region is set when OriginStack is one of several regional instances
+	// deployed from origin:regions; it suffixes resource names and SSM
+	// parameter paths so regions don't collide. Leave empty for a
+	// single-region deployment.
+	Region string
+
+	// CidrBlock, DbInstanceClass, and CacheNodeType carry the per-region
+	// values from origin:regionConfig. An empty CidrBlock falls back to
+	// network:cidr and then to 172.30.0.0/16; an empty DbInstanceClass or
+	// CacheNodeType means "use DataComponent's own default".
+	CidrBlock       string
+	DbInstanceClass string
+	CacheNodeType   string
+}
+
+// OriginStack is the whole Origin environment: network, data, mail, an EKS
+// cluster, and the SSM config that ties them together for the app to read
+// at runtime.
+type OriginStack struct {
+	pulumi.ResourceState
+
+	Network *NetworkComponent
+	Data    *DataComponent
+	Mail    *MailComponent
+	EKS     *eks.Cluster
+	Config  *ConfigComponent
+}
+
+// NewOriginStack wires up every subsystem in dependency order and
+// registers the whole thing as "origin:components:OriginStack".
+func NewOriginStack(ctx *pulumi.Context, name string, args *StackArgs, opts ...pulumi.ResourceOption) (*OriginStack, error) {
+	s := &OriginStack{}
+	if err := ctx.RegisterComponentResource("origin:components:OriginStack", name, s, opts...); err != nil {
+		return nil, err
+	}
+	parent := pulumi.Parent(s)
+
+	cfg := config.New(ctx, "")
+	networkCfg := config.New(ctx, "network")
+	eksCfg := config.New(ctx, "eks")
+
+	networkCidr := args.CidrBlock
+	if networkCidr == "" {
+		networkCidr = networkCfg.Get("cidr")
+	}
+	if networkCidr == "" {
+		networkCidr = "172.30.0.0/16"
+	}
+
+	netComp, err := NewNetworkComponent(ctx, name+"-network", &NetworkArgs{
+		Environment:    args.Environment,
+		CidrBlock:      networkCidr,
+		AzCount:        networkCfg.GetInt("azCount"),
+		EnableFlowLogs: networkCfg.GetBool("enableFlowLogs"),
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+	s.Network = netComp
+
+	mailComp, err := NewMailComponent(ctx, name+"-mail", &MailArgs{
+		Environment:  args.Environment,
+		Domain:       args.Domain,
+		HostedZoneID: cfg.Get("sesHostedZoneId"),
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+	s.Mail = mailComp
+
+	eksInstanceType := eksCfg.Get("instanceType")
+	if eksInstanceType == "" {
+		eksInstanceType = "t3.medium"
+	}
+	eksMinSize := eksCfg.GetInt("minSize")
+	if eksMinSize == 0 {
+		eksMinSize = 1
+	}
+	eksMaxSize := eksCfg.GetInt("maxSize")
+	if eksMaxSize == 0 {
+		eksMaxSize = 3
+	}
+	eksDesiredSize := eksCfg.GetInt("desiredSize")
+	if eksDesiredSize == 0 {
+		eksDesiredSize = eksMinSize
+	}
+
+	eksCluster, err := eks.NewCluster(ctx, name+"-eks", &eks.Args{
+		Environment:      args.Environment,
+		Region:           args.Region,
+		VpcID:            netComp.Outputs.VpcID,
+		PublicSubnetIDs:  netComp.Outputs.PublicSubnetIDs,
+		PrivateSubnetIDs: netComp.Outputs.PrivateSubnetIDs,
+		InstanceType:     eksInstanceType,
+		MinSize:          eksMinSize,
+		MaxSize:          eksMaxSize,
+		DesiredSize:      eksDesiredSize,
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+	s.EKS = eksCluster
+
+	dataComp, err := NewDataComponent(ctx, name+"-data", &DataArgs{
+		Environment:         args.Environment,
+		Region:              args.Region,
+		VpcID:               netComp.Outputs.VpcID,
+		PrivateSubnetIDs:    netComp.Outputs.PrivateSubnetIDs,
+		NodeSecurityGroupID: eksCluster.NodeSecurityGroupID,
+		DbInstanceClass:     args.DbInstanceClass,
+		CacheNodeType:       args.CacheNodeType,
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+	s.Data = dataComp
+
+	jwtSecret, err := secrets.NewJWTSecret(ctx, name+"-jwt-secret-value", parent)
+	if err != nil {
+		return nil, err
+	}
+
+	configComp, err := NewConfigComponent(ctx, name+"-config", &ConfigArgs{
+		Environment:    args.Environment,
+		Region:         args.Region,
+		DatabaseURL:    dataComp.Outputs.DatabaseURL,
+		RedisURL:       dataComp.Outputs.RedisURL,
+		JWTSecret:      pulumi.ToSecret(jwtSecret.Base64).(pulumi.StringOutput),
+		ClaudeAPIKey:   claudeAPIKey(cfg),
+		EKSClusterName: eksCluster.ClusterName,
+		EKSKubeconfig:  eksCluster.Kubeconfig,
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+	s.Config = configComp
+
+	if err := ctx.RegisterResourceOutputs(s, pulumi.Map{
+		"vpcId":          netComp.Outputs.VpcID,
+		"eksClusterName": eksCluster.ClusterName,
+	}); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// claudeAPIKey returns the configured claudeApiKey secret, or a clearly
+// fake placeholder when the stack hasn't set one. ssm.Parameter rejects an
+// empty Value, and cfg.GetSecret resolves to "" when the key is unset, so
+// passing it straight through would fail pulumi up on any stack that
+// hasn't configured the key yet.
+func claudeAPIKey(cfg *config.Config) pulumi.StringOutput {
+	if cfg.Get("claudeApiKey") == "" {
+		return pulumi.ToSecret(pulumi.String("PLACEHOLDER_UPDATE_MANUALLY")).(pulumi.StringOutput)
+	}
+	return cfg.GetSecret("claudeApiKey")
+}
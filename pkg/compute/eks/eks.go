@@ -0,0 +1,317 @@
+// Package eks provisions an EKS cluster and a managed node group for
+// running the Origin app's workloads inside the VPC built by pkg/network.
+package eks
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/ec2"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/eks"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/iam"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// Args configures the Cluster component.
+type Args struct {
+	// Environment prefixes resource names.
+	Environment string
+	// Region suffixes IAM role names (IAM is global, so a second region
+	// reusing the same Environment would otherwise collide). Empty for a
+	// single-region deployment.
+	Region string
+	// VpcID is the VPC the cluster and its node group run in.
+	VpcID pulumi.StringInput
+	// PrivateSubnetIDs are where the node group's EC2 instances launch.
+	PrivateSubnetIDs pulumi.StringArrayInput
+	// PublicSubnetIDs and PrivateSubnetIDs together make up the cluster's
+	// subnet set, so the control plane's ENIs can reach both.
+	PublicSubnetIDs pulumi.StringArrayInput
+	// InstanceType is the node group's EC2 instance type, e.g. "t3.medium".
+	InstanceType string
+	// MinSize, MaxSize, DesiredSize bound the managed node group.
+	MinSize     int
+	MaxSize     int
+	DesiredSize int
+}
+
+// Cluster is an EKS cluster plus a managed node group in the private
+// subnets, with the IAM roles and security groups EKS requires.
+type Cluster struct {
+	pulumi.ResourceState
+
+	Cluster   *eks.Cluster
+	NodeGroup *eks.NodeGroup
+	// NodeSecurityGroupID is the security group attached to the node
+	// group's instances, exposed so other components (e.g. RDS/
+	// ElastiCache security groups) can grant it default ingress.
+	NodeSecurityGroupID pulumi.IDOutput
+	ClusterName         pulumi.StringOutput
+	Kubeconfig          pulumi.StringOutput
+}
+
+// NewCluster builds the EKS subsystem described by args and registers it as
+// a ComponentResource named "origin:compute:eks:Cluster".
+func NewCluster(ctx *pulumi.Context, name string, args *Args, opts ...pulumi.ResourceOption) (*Cluster, error) {
+	c := &Cluster{}
+	if err := ctx.RegisterComponentResource("origin:compute:eks:Cluster", name, c, opts...); err != nil {
+		return nil, err
+	}
+
+	parent := pulumi.Parent(c)
+
+	namePrefix := args.Environment
+	if args.Region != "" {
+		namePrefix = fmt.Sprintf("%s-%s", args.Environment, args.Region)
+	}
+
+	clusterRole, err := iam.NewRole(ctx, fmt.Sprintf("%s-cluster-role", name), &iam.RoleArgs{
+		Name: pulumi.Sprintf("%s-origin-eks-cluster-role", namePrefix),
+		AssumeRolePolicy: pulumi.String(`{
+			"Version": "2012-10-17",
+			"Statement": [{
+				"Action": "sts:AssumeRole",
+				"Effect": "Allow",
+				"Principal": {"Service": "eks.amazonaws.com"}
+			}]
+		}`),
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, policyArn := range []string{
+		"arn:aws:iam::aws:policy/AmazonEKSClusterPolicy",
+		"arn:aws:iam::aws:policy/AmazonEKSVPCResourceController",
+	} {
+		if _, err := iam.NewRolePolicyAttachment(ctx, fmt.Sprintf("%s-cluster-role-policy-%d", name, i), &iam.RolePolicyAttachmentArgs{
+			Role:      clusterRole.Name,
+			PolicyArn: pulumi.String(policyArn),
+		}, parent); err != nil {
+			return nil, err
+		}
+	}
+
+	clusterSecurityGroup, err := newClusterSecurityGroup(ctx, name, args, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeSecurityGroup, err := newNodeSecurityGroup(ctx, name, args, parent)
+	if err != nil {
+		return nil, err
+	}
+	c.NodeSecurityGroupID = nodeSecurityGroup.ID()
+
+	if _, err := ec2.NewSecurityGroupRule(ctx, fmt.Sprintf("%s-cluster-sg-ingress-from-nodes", name), &ec2.SecurityGroupRuleArgs{
+		Type:                  pulumi.String("ingress"),
+		SecurityGroupId:       clusterSecurityGroup.ID(),
+		Protocol:              pulumi.String("tcp"),
+		FromPort:              pulumi.Int(443),
+		ToPort:                pulumi.Int(443),
+		SourceSecurityGroupId: nodeSecurityGroup.ID(),
+		Description:           pulumi.String("Node group access to the Kubernetes API"),
+	}, parent); err != nil {
+		return nil, err
+	}
+
+	if _, err := ec2.NewSecurityGroupRule(ctx, fmt.Sprintf("%s-node-sg-ingress-from-cluster", name), &ec2.SecurityGroupRuleArgs{
+		Type:                  pulumi.String("ingress"),
+		SecurityGroupId:       nodeSecurityGroup.ID(),
+		Protocol:              pulumi.String("-1"),
+		FromPort:              pulumi.Int(0),
+		ToPort:                pulumi.Int(0),
+		SourceSecurityGroupId: clusterSecurityGroup.ID(),
+		Description:           pulumi.String("Cluster control plane access to node kubelets"),
+	}, parent); err != nil {
+		return nil, err
+	}
+
+	subnetIds := pulumi.All(args.PublicSubnetIDs, args.PrivateSubnetIDs).ApplyT(func(ids []interface{}) []string {
+		all := append(append([]string{}, ids[0].([]string)...), ids[1].([]string)...)
+		return all
+	}).(pulumi.StringArrayOutput)
+
+	cluster, err := eks.NewCluster(ctx, fmt.Sprintf("%s-cluster", name), &eks.ClusterArgs{
+		Name:    pulumi.Sprintf("%s-origin", namePrefix),
+		RoleArn: clusterRole.Arn,
+		VpcConfig: &eks.ClusterVpcConfigArgs{
+			SubnetIds:        subnetIds,
+			SecurityGroupIds: pulumi.StringArray{clusterSecurityGroup.ID()},
+		},
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+	c.Cluster = cluster
+
+	nodeRole, err := iam.NewRole(ctx, fmt.Sprintf("%s-node-role", name), &iam.RoleArgs{
+		Name: pulumi.Sprintf("%s-origin-eks-node-role", namePrefix),
+		AssumeRolePolicy: pulumi.String(`{
+			"Version": "2012-10-17",
+			"Statement": [{
+				"Action": "sts:AssumeRole",
+				"Effect": "Allow",
+				"Principal": {"Service": "ec2.amazonaws.com"}
+			}]
+		}`),
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, policyArn := range []string{
+		"arn:aws:iam::aws:policy/AmazonEKSWorkerNodePolicy",
+		"arn:aws:iam::aws:policy/AmazonEKS_CNI_Policy",
+		"arn:aws:iam::aws:policy/AmazonEC2ContainerRegistryReadOnly",
+	} {
+		if _, err := iam.NewRolePolicyAttachment(ctx, fmt.Sprintf("%s-node-role-policy-%d", name, i), &iam.RolePolicyAttachmentArgs{
+			Role:      nodeRole.Name,
+			PolicyArn: pulumi.String(policyArn),
+		}, parent); err != nil {
+			return nil, err
+		}
+	}
+
+	nodeLaunchTemplate, err := ec2.NewLaunchTemplate(ctx, fmt.Sprintf("%s-node-launch-template", name), &ec2.LaunchTemplateArgs{
+		Name: pulumi.Sprintf("%s-origin-eks-node", namePrefix),
+		NetworkInterfaces: ec2.LaunchTemplateNetworkInterfaceArray{
+			&ec2.LaunchTemplateNetworkInterfaceArgs{
+				SecurityGroups: pulumi.StringArray{nodeSecurityGroup.ID()},
+			},
+		},
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeGroup, err := eks.NewNodeGroup(ctx, fmt.Sprintf("%s-node-group", name), &eks.NodeGroupArgs{
+		ClusterName:   cluster.Name,
+		NodeGroupName: pulumi.Sprintf("%s-origin-node-group", namePrefix),
+		NodeRoleArn:   nodeRole.Arn,
+		SubnetIds:     args.PrivateSubnetIDs,
+		InstanceTypes: pulumi.StringArray{pulumi.String(args.InstanceType)},
+		LaunchTemplate: &eks.NodeGroupLaunchTemplateArgs{
+			Id:      nodeLaunchTemplate.ID(),
+			Version: nodeLaunchTemplate.LatestVersion.ApplyT(func(v int) string { return fmt.Sprintf("%d", v) }).(pulumi.StringOutput),
+		},
+		ScalingConfig: &eks.NodeGroupScalingConfigArgs{
+			MinSize:     pulumi.Int(args.MinSize),
+			MaxSize:     pulumi.Int(args.MaxSize),
+			DesiredSize: pulumi.Int(args.DesiredSize),
+		},
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+	c.NodeGroup = nodeGroup
+
+	c.ClusterName = cluster.Name
+	c.Kubeconfig = pulumi.All(cluster.Name, cluster.Endpoint, cluster.CertificateAuthority).ApplyT(
+		func(args []interface{}) string {
+			clusterName := args[0].(string)
+			endpoint := args[1].(string)
+			ca := args[2].(eks.ClusterCertificateAuthority)
+			return fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: %s
+    certificate-authority-data: %s
+  name: %s
+contexts:
+- context:
+    cluster: %s
+    user: %s
+  name: %s
+current-context: %s
+users:
+- name: %s
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1beta1
+      command: aws
+      args:
+        - eks
+        - get-token
+        - --cluster-name
+        - %s
+`, endpoint, *ca.Data, clusterName, clusterName, clusterName, clusterName, clusterName, clusterName, clusterName)
+		}).(pulumi.StringOutput)
+
+	if err := ctx.RegisterResourceOutputs(c, pulumi.Map{
+		"clusterName": c.ClusterName,
+	}); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// newClusterSecurityGroup creates the security group attached to the EKS
+// control plane's ENIs. Its ingress (443 from the node security group) is
+// added separately as a SecurityGroupRule once both groups exist, rather
+// than a VPC-wide CIDR, so it stays correct regardless of what CIDR the
+// surrounding VPC happens to use.
+func newClusterSecurityGroup(ctx *pulumi.Context, name string, args *Args, parent pulumi.ResourceOption) (*ec2.SecurityGroup, error) {
+	namePrefix := args.Environment
+	if args.Region != "" {
+		namePrefix = fmt.Sprintf("%s-%s", args.Environment, args.Region)
+	}
+
+	return ec2.NewSecurityGroup(ctx, fmt.Sprintf("%s-cluster-sg", name), &ec2.SecurityGroupArgs{
+		Name:        pulumi.Sprintf("%s-origin-eks-cluster-sg", namePrefix),
+		Description: pulumi.String("Security group for the EKS control plane"),
+		VpcId:       args.VpcID,
+		Egress: ec2.SecurityGroupEgressArray{
+			&ec2.SecurityGroupEgressArgs{
+				Protocol:   pulumi.String("-1"),
+				FromPort:   pulumi.Int(0),
+				ToPort:     pulumi.Int(0),
+				CidrBlocks: pulumi.StringArray{pulumi.String("0.0.0.0/0")},
+			},
+		},
+	}, parent)
+}
+
+// newNodeSecurityGroup creates the security group attached to the node
+// group's EC2 instances via their launch template. Its ingress (all traffic
+// from the cluster security group, so the control plane can reach kubelets,
+// plus all traffic from itself for pod-to-pod networking) is added
+// separately as SecurityGroupRules once the cluster security group exists.
+func newNodeSecurityGroup(ctx *pulumi.Context, name string, args *Args, parent pulumi.ResourceOption) (*ec2.SecurityGroup, error) {
+	namePrefix := args.Environment
+	if args.Region != "" {
+		namePrefix = fmt.Sprintf("%s-%s", args.Environment, args.Region)
+	}
+
+	sg, err := ec2.NewSecurityGroup(ctx, fmt.Sprintf("%s-node-sg", name), &ec2.SecurityGroupArgs{
+		Name:        pulumi.Sprintf("%s-origin-eks-node-sg", namePrefix),
+		Description: pulumi.String("Security group for the EKS node group"),
+		VpcId:       args.VpcID,
+		Egress: ec2.SecurityGroupEgressArray{
+			&ec2.SecurityGroupEgressArgs{
+				Protocol:   pulumi.String("-1"),
+				FromPort:   pulumi.Int(0),
+				ToPort:     pulumi.Int(0),
+				CidrBlocks: pulumi.StringArray{pulumi.String("0.0.0.0/0")},
+			},
+		},
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := ec2.NewSecurityGroupRule(ctx, fmt.Sprintf("%s-node-sg-ingress-self", name), &ec2.SecurityGroupRuleArgs{
+		Type:                  pulumi.String("ingress"),
+		SecurityGroupId:       sg.ID(),
+		Protocol:              pulumi.String("-1"),
+		FromPort:              pulumi.Int(0),
+		ToPort:                pulumi.Int(0),
+		SourceSecurityGroupId: sg.ID(),
+		Description:           pulumi.String("Node to node pod networking"),
+	}, parent); err != nil {
+		return nil, err
+	}
+
+	return sg, nil
+}
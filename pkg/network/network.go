@@ -0,0 +1,273 @@
+// Package network provisions a dedicated VPC for the Origin stack: one
+// public and one private subnet per availability zone, an Internet Gateway
+// for the public side, a single NAT Gateway for private egress, and the
+// route tables wiring it all together. It replaces the old pattern of
+// looking up the account's default VPC/subnets.
+package network
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/cloudwatch"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/ec2"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/iam"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// Args configures the Network component.
+type Args struct {
+	// Environment is used to prefix resource names (e.g. "dev", "prod").
+	Environment string
+	// CidrBlock is the VPC's CIDR, e.g. "172.30.0.0/16".
+	CidrBlock string
+	// AzCount caps how many availability zones to spread subnets across.
+	// A value <= 0 means "use every AZ the region reports".
+	AzCount int
+	// EnableFlowLogs turns on a VPC Flow Log delivered to CloudWatch Logs.
+	EnableFlowLogs bool
+}
+
+// Network is a dedicated VPC with public/private subnets per AZ, an IGW,
+// a single NAT Gateway, and the associated route tables.
+type Network struct {
+	pulumi.ResourceState
+
+	Vpc              *ec2.Vpc
+	PublicSubnetIDs  pulumi.StringArrayOutput
+	PrivateSubnetIDs pulumi.StringArrayOutput
+	NatGatewayEIP    pulumi.StringOutput
+}
+
+// NewNetwork builds the VPC subsystem described by args and registers it as
+// a ComponentResource named "origin:network:Network".
+func NewNetwork(ctx *pulumi.Context, name string, args *Args, opts ...pulumi.ResourceOption) (*Network, error) {
+	n := &Network{}
+	if err := ctx.RegisterComponentResource("origin:network:Network", name, n, opts...); err != nil {
+		return nil, err
+	}
+
+	parent := pulumi.Parent(n)
+
+	vpc, err := ec2.NewVpc(ctx, fmt.Sprintf("%s-vpc", name), &ec2.VpcArgs{
+		CidrBlock:          pulumi.String(args.CidrBlock),
+		EnableDnsSupport:   pulumi.Bool(true),
+		EnableDnsHostnames: pulumi.Bool(true),
+		Tags: pulumi.StringMap{
+			"Name":        pulumi.Sprintf("%s-origin-vpc", args.Environment),
+			"Environment": pulumi.String(args.Environment),
+		},
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+	n.Vpc = vpc
+
+	igw, err := ec2.NewInternetGateway(ctx, fmt.Sprintf("%s-igw", name), &ec2.InternetGatewayArgs{
+		VpcId: vpc.ID(),
+		Tags: pulumi.StringMap{
+			"Name": pulumi.Sprintf("%s-origin-igw", args.Environment),
+		},
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	azs, err := aws.GetAvailabilityZones(ctx, &aws.GetAvailabilityZonesArgs{
+		State: pulumi.StringRef("available"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	azNames := azs.Names
+	if args.AzCount > 0 && args.AzCount < len(azNames) {
+		azNames = azNames[:args.AzCount]
+	}
+
+	publicRouteTable, err := ec2.NewRouteTable(ctx, fmt.Sprintf("%s-public-rt", name), &ec2.RouteTableArgs{
+		VpcId: vpc.ID(),
+		Routes: ec2.RouteTableRouteArray{
+			&ec2.RouteTableRouteArgs{
+				CidrBlock: pulumi.String("0.0.0.0/0"),
+				GatewayId: igw.ID(),
+			},
+		},
+		Tags: pulumi.StringMap{
+			"Name": pulumi.Sprintf("%s-origin-public-rt", args.Environment),
+		},
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	var publicSubnetIDs pulumi.StringArray
+	var privateSubnetIDs pulumi.StringArray
+	var firstPublicSubnetID pulumi.IDOutput
+
+	for i, az := range azNames {
+		publicSubnet, err := ec2.NewSubnet(ctx, fmt.Sprintf("%s-public-%d", name, i), &ec2.SubnetArgs{
+			VpcId:               vpc.ID(),
+			CidrBlock:           pulumi.String(subnetCidr(args.CidrBlock, i)),
+			AvailabilityZone:    pulumi.String(az),
+			MapPublicIpOnLaunch: pulumi.Bool(true),
+			Tags: pulumi.StringMap{
+				"Name":                   pulumi.Sprintf("%s-origin-public-%d", args.Environment, i),
+				"kubernetes.io/role/elb": pulumi.String("1"),
+			},
+		}, parent)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := ec2.NewRouteTableAssociation(ctx, fmt.Sprintf("%s-public-rta-%d", name, i), &ec2.RouteTableAssociationArgs{
+			SubnetId:     publicSubnet.ID(),
+			RouteTableId: publicRouteTable.ID(),
+		}, parent); err != nil {
+			return nil, err
+		}
+
+		privateSubnet, err := ec2.NewSubnet(ctx, fmt.Sprintf("%s-private-%d", name, i), &ec2.SubnetArgs{
+			VpcId:            vpc.ID(),
+			CidrBlock:        pulumi.String(subnetCidr(args.CidrBlock, i+len(azNames))),
+			AvailabilityZone: pulumi.String(az),
+			Tags: pulumi.StringMap{
+				"Name":                            pulumi.Sprintf("%s-origin-private-%d", args.Environment, i),
+				"kubernetes.io/role/internal-elb": pulumi.String("1"),
+			},
+		}, parent)
+		if err != nil {
+			return nil, err
+		}
+
+		publicSubnetIDs = append(publicSubnetIDs, publicSubnet.ID())
+		privateSubnetIDs = append(privateSubnetIDs, privateSubnet.ID())
+
+		if i == 0 {
+			firstPublicSubnetID = publicSubnet.ID()
+		}
+	}
+
+	natEip, err := ec2.NewEip(ctx, fmt.Sprintf("%s-nat-eip", name), &ec2.EipArgs{
+		Domain: pulumi.String("vpc"),
+		Tags: pulumi.StringMap{
+			"Name": pulumi.Sprintf("%s-origin-nat-eip", args.Environment),
+		},
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	natGateway, err := ec2.NewNatGateway(ctx, fmt.Sprintf("%s-nat", name), &ec2.NatGatewayArgs{
+		AllocationId: natEip.ID(),
+		SubnetId:     firstPublicSubnetID,
+		Tags: pulumi.StringMap{
+			"Name": pulumi.Sprintf("%s-origin-nat", args.Environment),
+		},
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	privateRouteTable, err := ec2.NewRouteTable(ctx, fmt.Sprintf("%s-private-rt", name), &ec2.RouteTableArgs{
+		VpcId: vpc.ID(),
+		Routes: ec2.RouteTableRouteArray{
+			&ec2.RouteTableRouteArgs{
+				CidrBlock:    pulumi.String("0.0.0.0/0"),
+				NatGatewayId: natGateway.ID(),
+			},
+		},
+		Tags: pulumi.StringMap{
+			"Name": pulumi.Sprintf("%s-origin-private-rt", args.Environment),
+		},
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range azNames {
+		if _, err := ec2.NewRouteTableAssociation(ctx, fmt.Sprintf("%s-private-rta-%d", name, i), &ec2.RouteTableAssociationArgs{
+			SubnetId:     privateSubnetIDs[i],
+			RouteTableId: privateRouteTable.ID(),
+		}, parent); err != nil {
+			return nil, err
+		}
+	}
+
+	if args.EnableFlowLogs {
+		logGroup, err := cloudwatch.NewLogGroup(ctx, fmt.Sprintf("%s-flow-log-group", name), &cloudwatch.LogGroupArgs{
+			Name:            pulumi.Sprintf("/origin/%s/vpc-flow-logs", args.Environment),
+			RetentionInDays: pulumi.Int(14),
+		}, parent)
+		if err != nil {
+			return nil, err
+		}
+
+		flowLogRole, err := iam.NewRole(ctx, fmt.Sprintf("%s-flow-log-role", name), &iam.RoleArgs{
+			Name: pulumi.Sprintf("%s-origin-flow-log-role", args.Environment),
+			AssumeRolePolicy: pulumi.String(`{
+				"Version": "2012-10-17",
+				"Statement": [{
+					"Action": "sts:AssumeRole",
+					"Effect": "Allow",
+					"Principal": {"Service": "vpc-flow-logs.amazonaws.com"}
+				}]
+			}`),
+		}, parent)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := iam.NewRolePolicy(ctx, fmt.Sprintf("%s-flow-log-policy", name), &iam.RolePolicyArgs{
+			Role: flowLogRole.ID(),
+			Policy: pulumi.String(`{
+				"Version": "2012-10-17",
+				"Statement": [{
+					"Effect": "Allow",
+					"Action": [
+						"logs:CreateLogStream",
+						"logs:PutLogEvents",
+						"logs:DescribeLogGroups",
+						"logs:DescribeLogStreams"
+					],
+					"Resource": "*"
+				}]
+			}`),
+		}, parent); err != nil {
+			return nil, err
+		}
+
+		if _, err := ec2.NewFlowLog(ctx, fmt.Sprintf("%s-flow-log", name), &ec2.FlowLogArgs{
+			VpcId:              vpc.ID(),
+			TrafficType:        pulumi.String("ALL"),
+			LogDestinationType: pulumi.String("cloud-watch-logs"),
+			LogGroupName:       logGroup.Name,
+			IamRoleArn:         flowLogRole.Arn,
+		}, parent); err != nil {
+			return nil, err
+		}
+	}
+
+	n.PublicSubnetIDs = publicSubnetIDs.ToStringArrayOutput()
+	n.PrivateSubnetIDs = privateSubnetIDs.ToStringArrayOutput()
+	n.NatGatewayEIP = natEip.PublicIp
+
+	if err := ctx.RegisterResourceOutputs(n, pulumi.Map{
+		"vpcId":            vpc.ID(),
+		"publicSubnetIds":  n.PublicSubnetIDs,
+		"privateSubnetIds": n.PrivateSubnetIDs,
+		"natGatewayEip":    n.NatGatewayEIP,
+	}); err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// subnetCidr carves the index'th /20 out of the VPC's /16 CIDR. It assumes a
+// /16 VPC block, which matches the default network:cidr of 172.30.0.0/16 and
+// leaves room for up to 16 subnets.
+func subnetCidr(vpcCidr string, index int) string {
+	var a, b int
+	fmt.Sscanf(vpcCidr, "%d.%d.", &a, &b)
+	return fmt.Sprintf("%d.%d.%d.0/20", a, b, index*16)
+}
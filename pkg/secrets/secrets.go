@@ -0,0 +1,31 @@
+// Package secrets generates random credentials with
+// pulumi-random instead of committing placeholder values to source, so the
+// Pulumi state is the only place the real values ever live (and only as
+// pulumi.Secret outputs).
+package secrets
+
+import (
+	"github.com/pulumi/pulumi-random/sdk/v4/go/random"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// NewDatabasePassword generates a 32-character RDS master password
+// containing letters, digits, and symbols. OverrideSpecial excludes the
+// URI delimiters #, ?, [, ], :, / and @; the symbols it does use (among
+// them %, < and >) still need percent-encoding inside a URL, which the
+// database-url built with net/url in DataComponent applies.
+func NewDatabasePassword(ctx *pulumi.Context, name string, opts ...pulumi.ResourceOption) (*random.RandomPassword, error) {
+	return random.NewRandomPassword(ctx, name, &random.RandomPasswordArgs{
+		Length:          pulumi.Int(32),
+		Special:         pulumi.Bool(true),
+		OverrideSpecial: pulumi.String("!$%&*()-_=+<>"),
+	}, opts...)
+}
+
+// NewJWTSecret generates a 64-byte signing secret, exposed base64-encoded
+// via its Base64 output.
+func NewJWTSecret(ctx *pulumi.Context, name string, opts ...pulumi.ResourceOption) (*random.RandomBytes, error) {
+	return random.NewRandomBytes(ctx, name, &random.RandomBytesArgs{
+		Length: pulumi.Int(64),
+	}, opts...)
+}
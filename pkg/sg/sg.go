@@ -0,0 +1,65 @@
+// Package sg builds ec2.SecurityGroup ingress rules from Pulumi stack
+// config instead of hard-coding CIDRs in Go, so environments like dev and
+// prod can diverge in who gets access to a database or cache without a
+// code change.
+package sg
+
+import (
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/ec2"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/config"
+)
+
+// Rule is one ingress rule as it appears in stack config, e.g.:
+//
+//	[{"protocol": "tcp", "fromPort": 5432, "toPort": 5432, "cidrBlocks": ["10.0.0.0/8"], "description": "VPC"}]
+type Rule struct {
+	Protocol         string   `json:"protocol"`
+	FromPort         int      `json:"fromPort"`
+	ToPort           int      `json:"toPort"`
+	CidrBlocks       []string `json:"cidrBlocks"`
+	SecurityGroupIds []string `json:"securityGroupIds"`
+	Description      string   `json:"description"`
+}
+
+// NewSecurityGroupFromConfig creates a SecurityGroup in vpcID whose ingress
+// rules are read from the stack config list at cfgKey (see Rule). cfgKey is
+// optional: if it's absent from stack config, the security group is created
+// with no ingress rules at all, which keeps this function (and anything
+// that builds on it) usable in unit tests that don't supply a full stack
+// config. Egress is left wide open (all protocols, 0.0.0.0/0), matching the
+// rest of this program's security groups.
+func NewSecurityGroupFromConfig(ctx *pulumi.Context, name string, vpcID pulumi.StringInput, cfgKey string, opts ...pulumi.ResourceOption) (*ec2.SecurityGroup, error) {
+	cfg := config.New(ctx, "")
+
+	var rules []Rule
+	if err := cfg.GetObject(cfgKey, &rules); err != nil {
+		return nil, err
+	}
+
+	ingress := make(ec2.SecurityGroupIngressArray, 0, len(rules))
+	for _, rule := range rules {
+		ingress = append(ingress, &ec2.SecurityGroupIngressArgs{
+			Protocol:       pulumi.String(rule.Protocol),
+			FromPort:       pulumi.Int(rule.FromPort),
+			ToPort:         pulumi.Int(rule.ToPort),
+			CidrBlocks:     pulumi.ToStringArray(rule.CidrBlocks),
+			SecurityGroups: pulumi.ToStringArray(rule.SecurityGroupIds),
+			Description:    pulumi.String(rule.Description),
+		})
+	}
+
+	return ec2.NewSecurityGroup(ctx, name, &ec2.SecurityGroupArgs{
+		Name:    pulumi.String(name),
+		VpcId:   vpcID,
+		Ingress: ingress,
+		Egress: ec2.SecurityGroupEgressArray{
+			&ec2.SecurityGroupEgressArgs{
+				Protocol:   pulumi.String("-1"),
+				FromPort:   pulumi.Int(0),
+				ToPort:     pulumi.Int(0),
+				CidrBlocks: pulumi.StringArray{pulumi.String("0.0.0.0/0")},
+			},
+		},
+	}, opts...)
+}